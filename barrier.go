@@ -48,6 +48,7 @@
 package barrier
 
 import (
+	"context"
 	"sync"
 )
 
@@ -56,7 +57,7 @@ type Barrier struct {
 	channel            chan struct{}
 	initOnce, fallOnce sync.Once
 
-	m sync.Mutex // Protects "forwards" and "backwards"
+	m sync.Mutex // Protects "forwards", "backwards", "cause" and "calls"
 	// List of barriers to forward to
 	forwards map[*Barrier]struct{}
 	// List of barriers that might hold a reference to this one.
@@ -64,6 +65,17 @@ type Barrier struct {
 	// unbounded memory growth.
 	backwards map[*Barrier]struct{}
 
+	// The error passed to FallWithCause, if any. Set at most once, at the
+	// same time as "channel" is closed.
+	cause error
+
+	// In-flight Do calls, keyed by the key passed to Do. A call is removed
+	// once its fn has returned.
+	calls map[string]*call
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
 	// An optional hook, which if set, is called exactly once when the first
 	// b.Fall() is invoked.
 	FallHook func()
@@ -72,11 +84,13 @@ type Barrier struct {
 func (b *Barrier) init() {
 	b.initOnce.Do(func() {
 		b.channel = make(chan struct{})
+		b.ctx, b.cancel = context.WithCancel(context.Background())
 
 		b.m.Lock()
 		defer b.m.Unlock()
 		b.forwards = map[*Barrier]struct{}{}
 		b.backwards = map[*Barrier]struct{}{}
+		b.calls = map[string]*call{}
 	})
 }
 
@@ -92,8 +106,9 @@ func (b *Barrier) Forward(f *Barrier) {
 
 		select {
 		case <-b.channel:
-			// Barrier has already fallen, forward the signal immediately
-			f.Fall()
+			// Barrier has already fallen, forward the signal (and its
+			// cause) immediately.
+			f.FallWithCause(b.cause)
 			return
 		default:
 		}
@@ -110,31 +125,82 @@ func (b *Barrier) Forward(f *Barrier) {
 // `b.Fall()` can be called any number of times and causes the channel returned
 // by `b.Barrier()` to become closed (permanently available for immediate reading)
 func (b *Barrier) Fall() {
+	b.FallWithCause(nil)
+}
+
+// FallWithCause is like Fall, but additionally records `err` as the reason
+// the barrier fell, retrievable afterwards via Cause(). Only the first call
+// to Fall or FallWithCause has any effect; a cause passed to a later call is
+// discarded. The cause is propagated to forwarded barriers, so a chain
+// `b -> f -> g` preserves the originating error at every level.
+//
+// Forwarding is applied iteratively rather than by recursing through
+// forward.FallWithCause: a long forward chain would otherwise grow the
+// goroutine stack and hold each barrier's lock across the nested call for
+// its entire duration. A visited set keyed by *Barrier also means a cycle
+// in the forward graph (however ill-advised) falls every barrier in it
+// exactly once instead of deadlocking.
+func (b *Barrier) FallWithCause(err error) {
 	b.init()
 
-	b.fallOnce.Do(func() {
-		b.m.Lock()
-		if b.FallHook != nil {
-			b.FallHook()
+	visited := map[*Barrier]struct{}{b: {}}
+	worklist := []*Barrier{b}
+	var fallen []*Barrier
+
+	for len(worklist) > 0 {
+		cur := worklist[0]
+		worklist = worklist[1:]
+		cur.init()
+
+		ran := false
+		cur.fallOnce.Do(func() {
+			ran = true
+			cur.m.Lock()
+			cur.cause = err
+			if cur.FallHook != nil {
+				cur.FallHook()
+			}
+			close(cur.channel)
+			cur.cancel()
+			cur.m.Unlock()
+		})
+		if !ran {
+			// Someone else already fell (or is falling) this barrier.
+			continue
 		}
-		close(b.channel)
-		b.m.Unlock()
-
-		// When `b` is fired, all `f`s are fired
-		for forward := range b.forwards {
-			forward.Fall()
+		fallen = append(fallen, cur)
+
+		// When `cur` is fired, all its forwards are fired too.
+		for forward := range cur.forwards {
+			if _, ok := visited[forward]; ok {
+				continue
+			}
+			visited[forward] = struct{}{}
+			worklist = append(worklist, forward)
 		}
-		b.forwards = nil // lose any references to f
+		cur.forwards = nil // lose any references to f
+	}
 
-		// When `f` is fired, no `b` ever needs to know about us anymore.
-		for backward := range b.backwards {
+	// When `f` is fired, no `b` ever needs to know about us anymore.
+	for _, cur := range fallen {
+		for backward := range cur.backwards {
 			func() {
 				backward.m.Lock()
 				defer backward.m.Unlock()
-				delete(backward.forwards, b)
+				delete(backward.forwards, cur)
 			}()
 		}
-	})
+	}
+}
+
+// Cause returns the error passed to the FallWithCause call that caused the
+// barrier to fall, or nil if the barrier hasn't fallen yet, or fell via
+// Fall (equivalently, FallWithCause(nil)).
+func (b *Barrier) Cause() error {
+	b.init()
+	b.m.Lock()
+	defer b.m.Unlock()
+	return b.cause
 }
 
 // When `b.Fall()` is called, the channel returned by Barrier() is closed
@@ -143,3 +209,11 @@ func (b *Barrier) Barrier() <-chan struct{} {
 	b.init()
 	return b.channel
 }
+
+// Context returns a context.Context which is cancelled when the barrier
+// falls. Its Err() follows the usual context.Canceled convention; use
+// Cause() to retrieve the actual error, if any, that the barrier fell with.
+func (b *Barrier) Context() context.Context {
+	b.init()
+	return b.ctx
+}
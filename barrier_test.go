@@ -1,6 +1,7 @@
 package barrier
 
 import (
+	"errors"
 	"sync"
 	"testing"
 )
@@ -59,6 +60,58 @@ func TestForwardFallen(t *testing.T) {
 	<-f.Barrier()
 }
 
+func TestFallWithCause(t *testing.T) {
+	var b Barrier
+	cause := errors.New("oh no")
+
+	b.FallWithCause(cause)
+	<-b.Barrier()
+
+	if got := b.Cause(); got != cause {
+		t.Fatalf("Cause() = %v, want %v", got, cause)
+	}
+
+	select {
+	case <-b.Context().Done():
+	default:
+		t.Fatal("Context() should be done once the barrier has fallen")
+	}
+
+	// The first cause wins.
+	b.FallWithCause(errors.New("too late"))
+	if got := b.Cause(); got != cause {
+		t.Fatalf("Cause() = %v, want %v", got, cause)
+	}
+}
+
+func TestForwardCause(t *testing.T) {
+	var b, f Barrier
+	cause := errors.New("oh no")
+
+	b.Forward(&f)
+	b.FallWithCause(cause)
+
+	<-f.Barrier()
+	if got := f.Cause(); got != cause {
+		t.Fatalf("f.Cause() = %v, want %v", got, cause)
+	}
+}
+
+func TestForwardFallenCause(t *testing.T) {
+	// Forwarding a barrier that has already fallen should propagate its
+	// cause immediately, just as forwarding before it falls does.
+	var b, f Barrier
+	cause := errors.New("oh no")
+
+	b.FallWithCause(cause)
+	b.Forward(&f)
+
+	<-f.Barrier()
+	if got := f.Cause(); got != cause {
+		t.Fatalf("f.Cause() = %v, want %v", got, cause)
+	}
+}
+
 func BenchmarkBarrier(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		var b Barrier
@@ -104,3 +157,32 @@ func BenchmarkForward3(b *testing.B) {
 		<-h.Barrier()
 	}
 }
+
+// BenchmarkForwardDeep exercises a forward chain deep enough (1000+ links)
+// that recursing through forward.Fall() would grow the goroutine stack
+// linearly; Fall is iterative, so this should run in roughly constant
+// stack space regardless of depth.
+func BenchmarkForwardDeep(b *testing.B) {
+	const depth = 1000
+
+	for i := 0; i < b.N; i++ {
+		chain := make([]Barrier, depth)
+		for i := 0; i < depth-1; i++ {
+			chain[i].Forward(&chain[i+1])
+		}
+		go chain[0].Fall()
+		<-chain[depth-1].Barrier()
+	}
+}
+
+func TestForwardCycle(t *testing.T) {
+	// A cycle in the forward graph (however ill-advised) should fall every
+	// barrier in it exactly once rather than deadlocking.
+	var a, b Barrier
+	a.Forward(&b)
+	b.Forward(&a)
+
+	a.Fall()
+	<-a.Barrier()
+	<-b.Barrier()
+}
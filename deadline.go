@@ -0,0 +1,42 @@
+package barrier
+
+import (
+	"context"
+	"time"
+)
+
+// ErrDeadlineExceeded is the cause recorded by FallAfter and FallAt when
+// their deadline passes, so Cause() can tell a timeout apart from any other
+// reason the barrier fell.
+var ErrDeadlineExceeded = context.DeadlineExceeded
+
+// FallAfter arranges for the barrier to fall after d has elapsed, unless
+// the returned cancel func is called first. It is equivalent to
+// time.AfterFunc(d, func() { b.FallWithCause(ErrDeadlineExceeded) }),
+// wrapped up so cancellation doesn't need to be hand-rolled at every call
+// site. cancel is safe to call any number of times, including after the
+// deadline has already passed.
+func (b *Barrier) FallAfter(d time.Duration) (cancel func()) {
+	b.init()
+	timer := time.AfterFunc(d, func() { b.FallWithCause(ErrDeadlineExceeded) })
+	return func() { timer.Stop() }
+}
+
+// FallAt is like FallAfter, but schedules the barrier to fall at time t
+// rather than after a duration.
+func (b *Barrier) FallAt(t time.Time) (cancel func()) {
+	return b.FallAfter(time.Until(t))
+}
+
+// WaitContext blocks until the barrier falls or ctx is done, whichever
+// happens first. It returns nil if the barrier fell, or ctx.Err() if ctx
+// was done first.
+func (b *Barrier) WaitContext(ctx context.Context) error {
+	b.init()
+	select {
+	case <-b.channel:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,59 @@
+package barrier
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestFallAfter(t *testing.T) {
+	var b Barrier
+	b.FallAfter(time.Millisecond)
+	<-b.Barrier()
+
+	if got := b.Cause(); got != ErrDeadlineExceeded {
+		t.Fatalf("Cause() = %v, want %v", got, ErrDeadlineExceeded)
+	}
+}
+
+func TestFallAfterCancel(t *testing.T) {
+	var b Barrier
+	cancel := b.FallAfter(time.Hour)
+	cancel()
+
+	select {
+	case <-b.Barrier():
+		t.Fatal("barrier should not have fallen; FallAfter was cancelled")
+	case <-time.After(10 * time.Millisecond):
+	}
+}
+
+func TestFallAt(t *testing.T) {
+	var b Barrier
+	b.FallAt(time.Now().Add(time.Millisecond))
+	<-b.Barrier()
+
+	if got := b.Cause(); got != ErrDeadlineExceeded {
+		t.Fatalf("Cause() = %v, want %v", got, ErrDeadlineExceeded)
+	}
+}
+
+func TestWaitContext(t *testing.T) {
+	var b Barrier
+	b.Fall()
+
+	if err := b.WaitContext(context.Background()); err != nil {
+		t.Fatalf("WaitContext() = %v, want nil", err)
+	}
+}
+
+func TestWaitContextCancelled(t *testing.T) {
+	var b Barrier
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := b.WaitContext(ctx); err != context.Canceled {
+		t.Fatalf("WaitContext() = %v, want context.Canceled", err)
+	}
+}
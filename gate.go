@@ -0,0 +1,93 @@
+package barrier
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// gateClosedBit marks a Gate as closed within its atomic state; the
+// remaining bits count callers that are currently between Enter and Leave.
+const gateClosedBit = 1 << 62
+
+// Gate is a ref-counted gate: Enter/Leave bracket units of in-flight work,
+// and Close stops further work from entering, then blocks until all work
+// already entered has left.
+//
+// The zero Gate is open and ready to use. Enter and Leave are a single
+// atomic add or CAS each, so they're cheap enough to wrap every unit of
+// work.
+type Gate struct {
+	// High bit: closed flag. Remaining bits: number of Enter calls that
+	// have not yet had a matching Leave.
+	state int64
+
+	initOnce sync.Once
+	done     chan struct{}
+
+	// Barrier, if non-nil, falls once Close has finished draining, i.e.
+	// once the gate is closed and the active count has reached zero. Set
+	// it before the first call to Enter, Leave or Close.
+	Barrier *Barrier
+}
+
+func (g *Gate) init() {
+	g.initOnce.Do(func() {
+		g.done = make(chan struct{})
+	})
+}
+
+// Enter attempts to enter the gate, returning false if the gate is already
+// closed. Every call that returns true must be matched with a call to
+// Leave.
+func (g *Gate) Enter() bool {
+	g.init()
+	for {
+		state := atomic.LoadInt64(&g.state)
+		if state&gateClosedBit != 0 {
+			return false
+		}
+		if atomic.CompareAndSwapInt64(&g.state, state, state+1) {
+			return true
+		}
+	}
+}
+
+// Leave marks a caller as having left the gate. It must be called exactly
+// once for every Enter call that returned true.
+func (g *Gate) Leave() {
+	state := atomic.AddInt64(&g.state, -1)
+	if state == gateClosedBit {
+		// The gate is closed and the active count has just reached zero:
+		// Close (if any is in progress) can now unblock.
+		g.init()
+		close(g.done)
+		if g.Barrier != nil {
+			g.Barrier.Fall()
+		}
+	}
+}
+
+// Close closes the gate, so that no further Enter calls succeed, then
+// blocks until every caller that already entered has called Leave. If
+// Barrier is set, it falls at the point the gate finishes draining. Close
+// may be called any number of times.
+func (g *Gate) Close() {
+	g.init()
+	for {
+		state := atomic.LoadInt64(&g.state)
+		if state&gateClosedBit != 0 {
+			break // Someone else already closed the gate.
+		}
+		if atomic.CompareAndSwapInt64(&g.state, state, state|gateClosedBit) {
+			if state == 0 {
+				// No callers were active when the gate closed.
+				close(g.done)
+				if g.Barrier != nil {
+					g.Barrier.Fall()
+				}
+			}
+			break
+		}
+	}
+	<-g.done
+}
@@ -0,0 +1,77 @@
+package barrier
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGate(t *testing.T) {
+	var g Gate
+
+	if !g.Enter() {
+		t.Fatal("Enter() should succeed on an open gate")
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		g.Close()
+	}()
+
+	// Wait for the closing goroutine's CAS to land before probing Enter.
+	for atomic.LoadInt64(&g.state)&gateClosedBit == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// The gate is closing but the caller above is still inside it, so new
+	// entries should be refused and Close should not yet have returned.
+	if g.Enter() {
+		t.Fatal("Enter() should fail once Close has been called")
+	}
+	select {
+	case <-done:
+		t.Fatal("Close() should block while a caller is still inside the gate")
+	default:
+	}
+
+	g.Leave()
+	<-done
+}
+
+func TestGateClosedEmpty(t *testing.T) {
+	var g Gate
+	g.Close() // Should return immediately; nothing was ever entered.
+
+	if g.Enter() {
+		t.Fatal("Enter() should fail on a closed gate")
+	}
+}
+
+func TestGateBarrier(t *testing.T) {
+	var g Gate
+	var b Barrier
+	g.Barrier = &b
+
+	if !g.Enter() {
+		t.Fatal("Enter() should succeed on an open gate")
+	}
+
+	var w sync.WaitGroup
+	w.Add(1)
+	go func() {
+		defer w.Done()
+		g.Close()
+	}()
+
+	select {
+	case <-b.Barrier():
+		t.Fatal("Barrier should not fall until the gate has drained")
+	default:
+	}
+
+	g.Leave()
+	<-b.Barrier()
+	w.Wait()
+}
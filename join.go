@@ -0,0 +1,31 @@
+package barrier
+
+import "sync/atomic"
+
+// Join returns a new Barrier that falls once every one of parents has
+// fallen -- the dual of Forward, which fires as soon as any one of them
+// falls. Join with no parents returns an already-fallen Barrier.
+func Join(parents ...*Barrier) *Barrier {
+	j := &Barrier{}
+
+	if len(parents) == 0 {
+		j.Fall()
+		return j
+	}
+
+	pending := int64(len(parents))
+	for _, p := range parents {
+		// proxy is forwarded-to by p, so it falls when (and only when) p
+		// does; its FallHook decrements the shared pending count and falls
+		// j once every parent has been accounted for.
+		proxy := &Barrier{}
+		proxy.FallHook = func() {
+			if atomic.AddInt64(&pending, -1) == 0 {
+				j.Fall()
+			}
+		}
+		p.Forward(proxy)
+	}
+
+	return j
+}
@@ -0,0 +1,64 @@
+package barrier
+
+import (
+	"testing"
+	"time"
+)
+
+func TestJoin(t *testing.T) {
+	var a, b, c Barrier
+
+	j := Join(&a, &b, &c)
+
+	select {
+	case <-j.Barrier():
+		t.Fatal("j should not fall until all parents have fallen")
+	default:
+	}
+
+	a.Fall()
+	b.Fall()
+
+	select {
+	case <-j.Barrier():
+		t.Fatal("j should not fall until all parents have fallen")
+	default:
+	}
+
+	c.Fall()
+	<-j.Barrier()
+}
+
+func TestJoinAlreadyFallen(t *testing.T) {
+	var a, b Barrier
+	a.Fall()
+	b.Fall()
+
+	j := Join(&a, &b)
+	<-j.Barrier()
+}
+
+func TestJoinNoParents(t *testing.T) {
+	j := Join()
+	<-j.Barrier()
+}
+
+func TestJoinConcurrent(t *testing.T) {
+	var barriers [10]Barrier
+	parents := make([]*Barrier, len(barriers))
+	for i := range barriers {
+		parents[i] = &barriers[i]
+	}
+
+	j := Join(parents...)
+
+	for i := range barriers {
+		go barriers[i].Fall()
+	}
+
+	select {
+	case <-j.Barrier():
+	case <-time.After(time.Second):
+		t.Fatal("j should have fallen once all parents fell")
+	}
+}
@@ -0,0 +1,61 @@
+package barrier
+
+import (
+	"fmt"
+	"sync"
+)
+
+// call is the in-flight or completed state of a single Do key.
+type call struct {
+	wg    sync.WaitGroup
+	err   error
+	panic interface{}
+}
+
+// Do calls fn, ensuring only one call for a given key is in flight at a
+// time: concurrent callers sharing a key block until the in-flight call
+// finishes and then share its result; fn runs again on the next call once
+// it has. If fn returns an error, or panics, b falls with that error (or
+// the panic, wrapped) as its Cause, and any panic is re-raised in every
+// caller that observed it.
+func (b *Barrier) Do(key string, fn func() error) error {
+	b.init()
+
+	b.m.Lock()
+	if c, ok := b.calls[key]; ok {
+		b.m.Unlock()
+		c.wg.Wait()
+		if c.panic != nil {
+			panic(c.panic)
+		}
+		return c.err
+	}
+	c := new(call)
+	c.wg.Add(1)
+	b.calls[key] = c
+	b.m.Unlock()
+
+	func() {
+		defer c.wg.Done()
+		defer func() {
+			if r := recover(); r != nil {
+				c.panic = r
+				b.FallWithCause(fmt.Errorf("barrier: Do(%q) panicked: %v", key, r))
+			}
+		}()
+		c.err = fn()
+	}()
+
+	b.m.Lock()
+	delete(b.calls, key)
+	b.m.Unlock()
+
+	if c.panic != nil {
+		panic(c.panic)
+	}
+	if c.err != nil {
+		b.FallWithCause(c.err)
+	}
+
+	return c.err
+}
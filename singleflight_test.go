@@ -0,0 +1,131 @@
+package barrier
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestDoRunsOnce(t *testing.T) {
+	var b Barrier
+	var calls, started int64
+	const n = 10
+
+	ready := make(chan struct{})
+
+	var w sync.WaitGroup
+	results := make([]error, n)
+	for i := range results {
+		w.Add(1)
+		go func(i int) {
+			defer w.Done()
+			if atomic.AddInt64(&started, 1) == n {
+				close(ready)
+			}
+			results[i] = b.Do("key", func() error {
+				atomic.AddInt64(&calls, 1)
+				<-ready // Hold fn open until every caller has arrived.
+				return nil
+			})
+		}(i)
+	}
+	w.Wait()
+
+	if calls != 1 {
+		t.Fatalf("fn called %d times, want 1", calls)
+	}
+	for i, err := range results {
+		if err != nil {
+			t.Fatalf("results[%d] = %v, want nil", i, err)
+		}
+	}
+}
+
+func TestDoFallsOnError(t *testing.T) {
+	var b Barrier
+	cause := errors.New("setup failed")
+
+	err := b.Do("key", func() error { return cause })
+	if err != cause {
+		t.Fatalf("Do() = %v, want %v", err, cause)
+	}
+
+	<-b.Barrier()
+	if got := b.Cause(); got != cause {
+		t.Fatalf("Cause() = %v, want %v", got, cause)
+	}
+}
+
+func TestDoDistinctKeys(t *testing.T) {
+	var b Barrier
+	var calls int64
+
+	b.Do("a", func() error { atomic.AddInt64(&calls, 1); return nil })
+	b.Do("b", func() error { atomic.AddInt64(&calls, 1); return nil })
+
+	if calls != 2 {
+		t.Fatalf("fn called %d times, want 2", calls)
+	}
+}
+
+func TestDoForgetsCompletedCall(t *testing.T) {
+	var b Barrier
+	var calls int64
+
+	fn := func() error { atomic.AddInt64(&calls, 1); return nil }
+
+	b.Do("key", fn)
+	b.Do("key", fn)
+
+	if calls != 2 {
+		t.Fatalf("fn called %d times, want 2 (later, non-overlapping calls should re-run fn)", calls)
+	}
+}
+
+func TestDoPanicPropagatesToWaiters(t *testing.T) {
+	var b Barrier
+
+	started := make(chan struct{})
+	arrived := make(chan struct{})
+	unblock := make(chan struct{})
+
+	var w sync.WaitGroup
+	w.Add(2)
+
+	var panics int64
+	run := func(first bool) {
+		defer w.Done()
+		defer func() {
+			if recover() != nil {
+				atomic.AddInt64(&panics, 1)
+			}
+		}()
+		if !first {
+			close(arrived)
+		}
+		b.Do("key", func() error {
+			if first {
+				close(started)
+				<-unblock
+			}
+			panic("fn blew up")
+		})
+	}
+
+	go run(true)
+	<-started
+	go run(false)
+	<-arrived // Hold the first fn open until the second caller has arrived.
+	close(unblock)
+	w.Wait()
+
+	if panics != 2 {
+		t.Fatalf("panics recovered = %d, want 2 (first caller and waiter)", panics)
+	}
+
+	<-b.Barrier()
+	if b.Cause() == nil {
+		t.Fatal("Cause() should be set after fn panicked")
+	}
+}